@@ -1,27 +1,106 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
 	"math"
+	"os"
+	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
-type BenchmarkResult struct {
-	Goroutines   int
+// memStatsDelta captures the runtime.MemStats counters that changed during
+// a single pass, sampled before and after with a runtime.GC() on both sides
+// to establish a clean baseline.
+type memStatsDelta struct {
+	totalAlloc  uint64 // bytes allocated during the pass (TotalAlloc delta)
+	mallocs     uint64 // allocation count during the pass (Mallocs delta)
+	numGC       uint64 // completed GC cycles during the pass (NumGC delta)
+	pauseNs     uint64 // cumulative STW pause time during the pass (PauseTotalNs delta)
+	heapObjects uint64 // live heap objects at the end of the pass
+}
+
+// Sample holds the raw outcome of a single pass of a configuration, before
+// aggregation across -count repeats.
+type Sample struct {
 	OpsPerSecond float64
-	MemoryAllocs uint64
 	TotalOps     uint64
+	Elapsed      time.Duration
+	Mem          *memStatsDelta // nil unless -benchmem is set
+}
+
+// MemProfile summarizes memory behavior for a configuration, mirroring the
+// columns `go test -benchmem` prints.
+type MemProfile struct {
+	BytesPerOp  float64
+	AllocsPerOp float64
+	MBPerSec    float64
+	GCs         float64
+	AvgGCPause  time.Duration
+	HeapObjects uint64 // peak observed across Samples
+}
+
+type BenchmarkResult struct {
+	Goroutines   int
+	OpsPerSecond float64 // mean ops/sec across Samples
+	TotalOps     uint64  // mean total ops across Samples
+	Samples      []Sample
+	StdDev       float64 // sample stddev of OpsPerSecond across Samples
+	Min          float64
+	Max          float64
+	CIMargin     float64     // +/- 95% confidence interval margin on OpsPerSecond; 0 if count < 2
+	MemProfile   *MemProfile // nil unless -benchmem is set
 }
 
 type Benchmark struct {
 	duration    time.Duration
+	iterations  int  // if > 0, run exactly this many inner-loop iterations per goroutine instead of sleeping for duration
+	ramp        bool // if true (and iterations == 0), ramp iterations up to hit duration instead of sleeping for it
 	maxRoutines int
-	testType    string
+	format      string
+	count       int
+	benchmem    bool
+}
+
+// measureMemStats runs the pass produced by run, optionally bracketing it
+// with runtime.GC()+ReadMemStats calls (as the standard benchmem examples
+// do) to capture allocation and GC activity during the pass.
+func (b *Benchmark) measureMemStats(run func() (uint64, time.Duration)) (uint64, time.Duration, memStatsDelta) {
+	if !b.benchmem {
+		ops, elapsed := run()
+		return ops, elapsed, memStatsDelta{}
+	}
+
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	ops, elapsed := run()
+
+	var afterRun runtime.MemStats
+	runtime.ReadMemStats(&afterRun)
+
+	// The closing GC below is only to get a clean HeapObjects snapshot;
+	// it must not be allowed to inflate the NumGC/PauseTotalNs deltas with
+	// a collection that the workload itself never triggered.
+	runtime.GC()
+	var afterGC runtime.MemStats
+	runtime.ReadMemStats(&afterGC)
+
+	return ops, elapsed, memStatsDelta{
+		totalAlloc:  afterRun.TotalAlloc - before.TotalAlloc,
+		mallocs:     afterRun.Mallocs - before.Mallocs,
+		numGC:       uint64(afterRun.NumGC - before.NumGC),
+		pauseNs:     afterRun.PauseTotalNs - before.PauseTotalNs,
+		heapObjects: afterGC.HeapObjects,
+	}
 }
 
 func formatNumber(n float64) string {
@@ -47,208 +126,363 @@ func formatNumber(n float64) string {
 	return result + ".00"
 }
 
+// durationOrCountFlag implements flag.Value, modeled on the unexported
+// flag type go test uses for -benchtime: it accepts either a wall-clock
+// time.Duration ("10s", "2m") or, with an "x" suffix, an exact iteration
+// count ("10000x") meaning "run exactly N iterations per goroutine".
+type durationOrCountFlag struct {
+	d time.Duration
+	n int
+}
+
+func (f *durationOrCountFlag) String() string {
+	if f.n > 0 {
+		return fmt.Sprintf("%dx", f.n)
+	}
+	return f.d.String()
+}
+
+func (f *durationOrCountFlag) Set(s string) error {
+	if strings.HasSuffix(s, "x") {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid count %q: must be a positive integer followed by x", s)
+		}
+		*f = durationOrCountFlag{n: n}
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid duration %q: must be a duration like 10s or a count like 10000x", s)
+	}
+	*f = durationOrCountFlag{d: d}
+	return nil
+}
+
 func main() {
-	duration := flag.Int("d", 10, "Duration of each test in seconds")
-	testType := flag.String("t", "both", "Type of test: cpu, memory, or both")
+	dFlag := &durationOrCountFlag{d: 10 * time.Second}
+	flag.Var(dFlag, "d", "Duration of each test (e.g. 10s) or exact iteration count per goroutine (e.g. 10000x)")
+	runPattern := flag.String("run", "CPU|Memory", "Regexp selecting which registered workloads to run, by name (e.g. -run=. for all)")
+	format := flag.String("format", "pretty", "Output format: pretty or go (benchstat-compatible)")
+	count := flag.Int("count", 1, "Run each configuration N times and report mean/stddev/95% CI")
+	benchmem := flag.Bool("benchmem", false, "Report memory allocation statistics (B/op, allocs/op, MB/s, GCs, avg GC pause)")
+	ramp := flag.Bool("ramp", false, "Auto-scale iterations per goroutine to hit -d as a target time, like go test's benchtime ramp, instead of sleeping for it")
 	flag.Parse()
 
+	dWasSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "d" {
+			dWasSet = true
+		}
+	})
+	if *ramp && dFlag.n == 0 && !dWasSet {
+		dFlag.d = time.Second
+	}
+
+	re, err := regexp.Compile(*runPattern)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -run pattern %q: %v\n", *runPattern, err)
+		os.Exit(1)
+	}
+
+	var selected []Workload
+	for _, name := range registryOrder {
+		if re.MatchString(name) {
+			selected = append(selected, registry[name])
+		}
+	}
+	if len(selected) == 0 {
+		fmt.Fprintf(os.Stderr, "no registered workload matches -run=%q\n", *runPattern)
+		os.Exit(1)
+	}
+
 	maxRoutines := runtime.NumCPU() * 2
 
 	bench := &Benchmark{
-		duration:    time.Duration(*duration) * time.Second,
+		duration:    dFlag.d,
+		iterations:  dFlag.n,
+		ramp:        *ramp,
 		maxRoutines: maxRoutines,
-		testType:    *testType,
+		format:      *format,
+		count:       *count,
+		benchmem:    *benchmem,
 	}
 
-	fmt.Printf("🚀 Go Goroutine Benchmark Tool\n")
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	fmt.Printf("Logical CPUs: %d\n", runtime.NumCPU())
-	fmt.Printf("Test Duration: %v per configuration\n", bench.duration)
-	fmt.Printf("Max Goroutines: %d\n", bench.maxRoutines)
-	fmt.Printf("Test Type: %s\n\n", bench.testType)
-
-	var cpuResults []BenchmarkResult
-	var memoryResults []BenchmarkResult
+	names := make([]string, len(selected))
+	for i, w := range selected {
+		names[i] = w.Name()
+	}
 
-	if bench.testType == "cpu" || bench.testType == "both" {
-		fmt.Printf("📊 CPU Benchmark\n")
+	if bench.format == "go" {
+		bench.printGoFormatHeader()
+	} else {
+		fmt.Printf("🚀 Go Goroutine Benchmark Tool\n")
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-		cpuResults = bench.runCPUBenchmark()
+		fmt.Printf("Logical CPUs: %d\n", runtime.NumCPU())
+		if bench.iterations > 0 {
+			fmt.Printf("Iterations: %dx per goroutine per configuration\n", bench.iterations)
+		} else if bench.ramp {
+			fmt.Printf("Target Duration: %v per configuration (ramping iterations to reach it)\n", bench.duration)
+		} else {
+			fmt.Printf("Test Duration: %v per configuration\n", bench.duration)
+		}
+		fmt.Printf("Max Goroutines: %d\n", bench.maxRoutines)
+		fmt.Printf("Workloads: %s\n\n", strings.Join(names, ", "))
 	}
 
-	if bench.testType == "memory" || bench.testType == "both" {
-		fmt.Printf("\n📊 Memory Benchmark\n")
-		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-		memoryResults = bench.runMemoryBenchmark()
+	results := make([][]BenchmarkResult, len(selected))
+	for i, w := range selected {
+		if bench.format != "go" {
+			fmt.Printf("📊 %s Benchmark\n", w.Name())
+			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		}
+		results[i] = bench.runWorkloadBenchmark(w)
 	}
 
 	// Print all results at the end
-	if len(cpuResults) > 0 {
-		bench.printResults(cpuResults, "CPU")
+	for i, w := range selected {
+		if len(results[i]) > 0 {
+			bench.printResults(results[i], w.Name())
+		}
 	}
 
-	if len(memoryResults) > 0 {
-		bench.printResults(memoryResults, "Memory")
+	if bench.format != "go" {
+		fmt.Println()
 	}
+}
 
-	fmt.Println()
+// printGoFormatHeader emits the goos/goarch/pkg/cpu preamble benchstat
+// expects at the top of a results file, before any Benchmark lines.
+func (b *Benchmark) printGoFormatHeader() {
+	fmt.Printf("goos: %s\n", runtime.GOOS)
+	fmt.Printf("goarch: %s\n", runtime.GOARCH)
+	fmt.Printf("pkg: go-bench\n")
+	fmt.Printf("cpu: %s\n", cpuModelName())
 }
 
-func (b *Benchmark) runCPUBenchmark() []BenchmarkResult {
+// cpuModelName returns a human-readable CPU identifier for the "cpu:"
+// header line, falling back to a logical core count when the platform
+// doesn't expose a model name (e.g. non-Linux or sandboxed environments).
+func cpuModelName() string {
+	f, err := os.Open("/proc/cpuinfo")
+	if err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, "model name") {
+				parts := strings.SplitN(line, ":", 2)
+				if len(parts) == 2 {
+					return strings.TrimSpace(parts[1])
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("%d logical CPUs", runtime.NumCPU())
+}
+
+// runWorkloadBenchmark drives w across every goroutine-count configuration
+// in b.getTestCases, returning one aggregated BenchmarkResult per
+// configuration.
+func (b *Benchmark) runWorkloadBenchmark(w Workload) []BenchmarkResult {
 	var results []BenchmarkResult
 	testCases := b.getTestCases()
 
 	for _, numGoroutines := range testCases {
-		fmt.Printf("\n▶ Testing with %d goroutines...\n", numGoroutines)
-		result := b.benchmarkCPU(numGoroutines)
+		if b.format != "go" {
+			fmt.Printf("\n▶ Testing with %d goroutines...\n", numGoroutines)
+		}
+		result := b.benchmarkWorkload(w, numGoroutines)
 		results = append(results, result)
 
-		fmt.Printf("  ✓ Operations: %d | Rate: %.2f ops/sec\n",
-			result.TotalOps, result.OpsPerSecond)
+		if b.format != "go" {
+			fmt.Printf("  ✓ Operations: %d | Rate: %.2f ops/sec\n",
+				result.TotalOps, result.OpsPerSecond)
+		}
 	}
 
 	return results
 }
 
-func (b *Benchmark) benchmarkCPU(numGoroutines int) BenchmarkResult {
-	var totalOps uint64
-	var wg sync.WaitGroup
-	stop := make(chan bool)
+// benchmarkWorkload runs w for numGoroutines across b.count repeats
+// (mirroring go test -count) and aggregates the samples into a single
+// BenchmarkResult with mean/stddev/95% CI. w.Setup/Teardown bracket each
+// repeat so workloads with shared state (counters, mutexes, ...) start
+// from a clean slate every time.
+func (b *Benchmark) benchmarkWorkload(w Workload, numGoroutines int) BenchmarkResult {
+	runs := b.count
+	if runs < 1 {
+		runs = 1
+	}
+
+	samples := make([]Sample, 0, runs)
+	for i := 0; i < runs; i++ {
+		w.Setup()
+
+		// Ramp mode has to probe at several iteration counts before it knows
+		// how big the real pass is; that probing must happen outside the
+		// measureMemStats bracket below, or the thrown-away warm-up passes'
+		// allocations get counted against the final pass's op count.
+		rampN := 0
+		if b.iterations == 0 && b.ramp {
+			rampN = b.rampSize(w, numGoroutines)
+		}
+
+		finalOps, elapsed, mem := b.measureMemStats(func() (uint64, time.Duration) {
+			switch {
+			case b.iterations > 0:
+				return b.runWorkloadPassFixed(w, numGoroutines)
+			case b.ramp:
+				return runWorkloadPassN(w, numGoroutines, rampN)
+			default:
+				return b.runWorkloadPassDuration(w, numGoroutines), b.duration
+			}
+		})
+		w.Teardown()
+
+		opsPerSecond := float64(finalOps) / elapsed.Seconds()
+		sample := Sample{OpsPerSecond: opsPerSecond, TotalOps: finalOps, Elapsed: elapsed}
+		if b.benchmem {
+			sample.Mem = &mem
+		}
+		samples = append(samples, sample)
+	}
+
+	return aggregateSamples(numGoroutines, samples)
+}
+
+// runWorkloadPassFixed runs exactly b.iterations calls to w.Step per
+// goroutine and reports the wall time actually elapsed, for deterministic,
+// reproducible runs (-d=Nx) independent of system load.
+func (b *Benchmark) runWorkloadPassFixed(w Workload, numGoroutines int) (uint64, time.Duration) {
+	return runWorkloadPassN(w, numGoroutines, b.iterations)
+}
 
-	progressTicker := time.NewTicker(time.Second)
-	defer progressTicker.Stop()
+// runWorkloadPassN runs exactly n calls to w.Step per goroutine and reports
+// the wall time actually elapsed. It's the inner primitive both
+// runWorkloadPassFixed (fixed n) and rampSize (ramping n) drive.
+func runWorkloadPassN(w Workload, numGoroutines, n int) (uint64, time.Duration) {
+	var wg sync.WaitGroup
 
+	start := time.Now()
 	for i := 0; i < numGoroutines; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			var localOps uint64
-			for {
-				select {
-				case <-stop:
-					atomic.AddUint64(&totalOps, localOps)
-					return
-				default:
-					result := 0.0
-					for j := 0; j < 100; j++ {
-						result += math.Sqrt(float64(j))
-						result *= 1.0001
-						result = math.Sin(result) + math.Cos(result)
-					}
-					localOps++
-
-					if localOps%10000 == 0 {
-						atomic.AddUint64(&totalOps, 10000)
-						localOps -= 10000
-					}
-				}
+			for k := 0; k < n; k++ {
+				w.Step()
 			}
 		}()
 	}
-
-	go func() {
-		for {
-			select {
-			case <-progressTicker.C:
-				// Progress ticker removed with verbose option
-			case <-stop:
-				return
-			}
-		}
-	}()
-
-	time.Sleep(b.duration)
-	close(stop)
 	wg.Wait()
+	elapsed := time.Since(start)
 
-	finalOps := atomic.LoadUint64(&totalOps)
-	opsPerSecond := float64(finalOps) / b.duration.Seconds()
+	return uint64(numGoroutines) * uint64(n), elapsed
+}
 
-	return BenchmarkResult{
-		Goroutines:   numGoroutines,
-		OpsPerSecond: opsPerSecond,
-		TotalOps:     finalOps,
+// rampSize ports the iteration-ramp algorithm go test uses to size a
+// benchmark's N: start at n=1, time the pass, and scale n toward whatever
+// would have made the pass take b.duration, repeating until a pass actually
+// reaches b.duration (or n hits maxRampN). It returns the sized n for the
+// caller to re-run as the real, measured pass; the probe passes run here are
+// discarded, so they must never be the pass mem stats get measured around.
+func (b *Benchmark) rampSize(w Workload, numGoroutines int) int {
+	const maxRampN = 1_000_000_000
+
+	n := 1
+	for {
+		_, elapsed := runWorkloadPassN(w, numGoroutines, n)
+		if elapsed >= b.duration || n >= maxRampN {
+			return n
+		}
+		n = nextRampN(n, elapsed, b.duration, maxRampN)
 	}
 }
 
-func (b *Benchmark) runMemoryBenchmark() []BenchmarkResult {
-	var results []BenchmarkResult
-	testCases := b.getTestCases()
+// nextRampN predicts the next iteration count to try, extrapolating from
+// how long n iterations took toward how many would take target, capped at
+// 100x growth per step (so a near-zero first pass can't overshoot wildly)
+// and rounded up to a "nice" number (1, 2, 3, 5, 10, 20, ...) the way
+// go test rounds b.N between ramp steps.
+func nextRampN(n int, elapsed, target time.Duration, maxN int) int {
+	if elapsed <= 0 {
+		elapsed = time.Nanosecond
+	}
 
-	for _, numGoroutines := range testCases {
-		fmt.Printf("\n▶ Testing with %d goroutines...\n", numGoroutines)
-		result := b.benchmarkMemory(numGoroutines)
-		results = append(results, result)
+	next := int64(float64(n) * (target.Seconds() / elapsed.Seconds()))
+	if max := int64(n) * 100; next > max {
+		next = max
+	}
+	if next <= int64(n) {
+		next = int64(n) + 1
+	}
+	if next > int64(maxN) {
+		next = int64(maxN)
+	}
 
-		fmt.Printf("  ✓ Allocations: %d | Rate: %.2f allocs/sec\n",
-			result.MemoryAllocs, result.OpsPerSecond)
+	rounded := roundUpNice(int(next))
+	if rounded > maxN {
+		rounded = maxN
 	}
+	return rounded
+}
 
-	return results
+// roundUpNice rounds n up to the nearest value in the 1, 2, 3, 5, 10, 20,
+// 30, 50, 100, ... sequence, so ramp steps land on readable iteration
+// counts instead of whatever the raw extrapolation produced.
+func roundUpNice(n int) int {
+	if n < 1 {
+		return 1
+	}
+
+	pow := 1
+	for pow*10 <= n {
+		pow *= 10
+	}
+	for _, mult := range []int{1, 2, 3, 5, 10} {
+		if v := pow * mult; v >= n {
+			return v
+		}
+	}
+	return pow * 10
 }
 
-func (b *Benchmark) benchmarkMemory(numGoroutines int) BenchmarkResult {
-	var totalAllocs uint64
+// runWorkloadPassDuration calls w.Step in a tight loop from numGoroutines
+// goroutines for b.duration and returns the total number of calls observed.
+func (b *Benchmark) runWorkloadPassDuration(w Workload, numGoroutines int) uint64 {
+	var totalOps uint64
 	var wg sync.WaitGroup
 	stop := make(chan bool)
 
-	progressTicker := time.NewTicker(time.Second)
-	defer progressTicker.Stop()
-
 	for i := 0; i < numGoroutines; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			var localAllocs uint64
+			var localOps uint64
 			for {
 				select {
 				case <-stop:
-					atomic.AddUint64(&totalAllocs, localAllocs)
+					atomic.AddUint64(&totalOps, localOps)
 					return
 				default:
-					sizes := []int{64, 256, 1024, 4096}
-					for _, size := range sizes {
-						buffer := make([]byte, size)
-						for j := range buffer {
-							buffer[j] = byte(j % 256)
-						}
-						_ = buffer
-						localAllocs++
-					}
+					w.Step()
+					localOps++
 
-					if localAllocs%1000 == 0 {
-						atomic.AddUint64(&totalAllocs, 1000)
-						localAllocs -= 1000
+					if localOps%10000 == 0 {
+						atomic.AddUint64(&totalOps, 10000)
+						localOps -= 10000
 					}
 				}
 			}
 		}()
 	}
 
-	go func() {
-		for {
-			select {
-			case <-progressTicker.C:
-				// Progress ticker removed with verbose option
-			case <-stop:
-				return
-			}
-		}
-	}()
-
 	time.Sleep(b.duration)
 	close(stop)
 	wg.Wait()
 
-	finalAllocs := atomic.LoadUint64(&totalAllocs)
-	allocsPerSecond := float64(finalAllocs) / b.duration.Seconds()
-
-	return BenchmarkResult{
-		Goroutines:   numGoroutines,
-		OpsPerSecond: allocsPerSecond,
-		MemoryAllocs: finalAllocs,
-		TotalOps:     finalAllocs,
-	}
+	return atomic.LoadUint64(&totalOps)
 }
 
 func (b *Benchmark) getTestCases() []int {
@@ -270,7 +504,146 @@ func (b *Benchmark) getTestCases() []int {
 		testCases = append(testCases, cpuCount*2)
 	}
 
-	return testCases
+	// On low core counts (e.g. cpuCount/2 == 1) some of the entries above
+	// collide; dedupe so the optimal-configuration ranking never compares
+	// a goroutine count against an identical copy of itself.
+	unique := testCases[:0]
+	seen := make(map[int]bool, len(testCases))
+	for _, n := range testCases {
+		if !seen[n] {
+			seen[n] = true
+			unique = append(unique, n)
+		}
+	}
+
+	return unique
+}
+
+// aggregateSamples reduces repeated passes of the same configuration into a
+// single BenchmarkResult, computing mean/stddev/min/max and a 95% confidence
+// interval (via Student's t, appropriate for the small sample counts -count
+// realistically produces) on OpsPerSecond.
+func aggregateSamples(numGoroutines int, samples []Sample) BenchmarkResult {
+	n := len(samples)
+	result := BenchmarkResult{
+		Goroutines: numGoroutines,
+		Samples:    samples,
+	}
+	if n == 0 {
+		return result
+	}
+
+	var opsSum float64
+	var totalOpsSum uint64
+	result.Min = samples[0].OpsPerSecond
+	result.Max = samples[0].OpsPerSecond
+	for _, s := range samples {
+		opsSum += s.OpsPerSecond
+		totalOpsSum += s.TotalOps
+		if s.OpsPerSecond < result.Min {
+			result.Min = s.OpsPerSecond
+		}
+		if s.OpsPerSecond > result.Max {
+			result.Max = s.OpsPerSecond
+		}
+	}
+	result.OpsPerSecond = opsSum / float64(n)
+	result.TotalOps = totalOpsSum / uint64(n)
+	result.MemProfile = aggregateMemProfile(samples)
+
+	if n < 2 {
+		return result
+	}
+
+	var sqDiffSum float64
+	for _, s := range samples {
+		d := s.OpsPerSecond - result.OpsPerSecond
+		sqDiffSum += d * d
+	}
+	result.StdDev = math.Sqrt(sqDiffSum / float64(n-1))
+	result.CIMargin = tValue(n-1) * result.StdDev / math.Sqrt(float64(n))
+
+	return result
+}
+
+// aggregateMemProfile averages the per-sample memStatsDelta readings into a
+// MemProfile, matching the columns `go test -benchmem` reports. It returns
+// nil when samples carry no memory data (i.e. -benchmem was not set).
+func aggregateMemProfile(samples []Sample) *MemProfile {
+	n := 0
+	var bytesPerOpSum, allocsPerOpSum, mbPerSecSum, gcSum float64
+	var pauseNsSum, pauseCount uint64
+	var heapObjectsPeak uint64
+
+	for _, s := range samples {
+		if s.Mem == nil {
+			continue
+		}
+		n++
+
+		if s.TotalOps > 0 {
+			bytesPerOpSum += float64(s.Mem.totalAlloc) / float64(s.TotalOps)
+			allocsPerOpSum += float64(s.Mem.mallocs) / float64(s.TotalOps)
+		}
+		if s.Elapsed > 0 {
+			mbPerSecSum += (float64(s.Mem.totalAlloc) / 1e6) / s.Elapsed.Seconds()
+		}
+		gcSum += float64(s.Mem.numGC)
+		if s.Mem.numGC > 0 {
+			pauseNsSum += s.Mem.pauseNs
+			pauseCount += s.Mem.numGC
+		}
+		if s.Mem.heapObjects > heapObjectsPeak {
+			heapObjectsPeak = s.Mem.heapObjects
+		}
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	profile := &MemProfile{
+		BytesPerOp:  bytesPerOpSum / float64(n),
+		AllocsPerOp: allocsPerOpSum / float64(n),
+		MBPerSec:    mbPerSecSum / float64(n),
+		GCs:         gcSum / float64(n),
+		HeapObjects: heapObjectsPeak,
+	}
+	if pauseCount > 0 {
+		profile.AvgGCPause = time.Duration(pauseNsSum/pauseCount) * time.Nanosecond
+	}
+	return profile
+}
+
+// tValue returns the two-tailed 95% Student's t critical value for the given
+// degrees of freedom, from a table covering df 1 through 30. Past df=30 it
+// returns the normal approximation z=1.96, which is slightly below the true
+// t value there (about 2.040 at df=31).
+func tValue(df int) float64 {
+	table := []float64{
+		12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+		2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+		2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+	}
+	if df < 1 {
+		df = 1
+	}
+	if df <= len(table) {
+		return table[df-1]
+	}
+	return 1.960
+}
+
+// ciOverlaps reports whether a and b's 95% confidence intervals on
+// OpsPerSecond overlap, meaning neither can be declared the winner over the
+// other. A zero CIMargin (fewer than 2 samples) means no interval was
+// computed, so it never overlaps.
+func ciOverlaps(a, b BenchmarkResult) bool {
+	if a.CIMargin <= 0 || b.CIMargin <= 0 {
+		return false
+	}
+	return a.OpsPerSecond-a.CIMargin <= b.OpsPerSecond+b.CIMargin &&
+		b.OpsPerSecond-b.CIMargin <= a.OpsPerSecond+a.CIMargin
 }
 
 func (b *Benchmark) printResults(results []BenchmarkResult, testType string) {
@@ -278,30 +651,60 @@ func (b *Benchmark) printResults(results []BenchmarkResult, testType string) {
 		return
 	}
 
+	if b.format == "go" {
+		b.printResultsGoFormat(results, testType)
+		return
+	}
+
 	fmt.Printf("\n📈 %s Benchmark Results\n", testType)
 	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	fmt.Printf("%-12s | %-15s | %-12s\n", "Goroutines", "Ops/Second", "Total Ops")
+	if b.benchmem {
+		fmt.Printf("%-12s | %-15s | %-12s | %-12s | %-12s | %-12s | %-12s | %-10s | %-12s | %-10s | %-6s | %-12s\n",
+			"Goroutines", "Ops/Second", "Total Ops", "StdDev", "Min", "Max", "±95% CI", "B/op", "allocs/op", "MB/s", "GCs", "avg GC pause")
+	} else {
+		fmt.Printf("%-12s | %-15s | %-12s | %-12s | %-12s | %-12s | %-12s\n",
+			"Goroutines", "Ops/Second", "Total Ops", "StdDev", "Min", "Max", "±95% CI")
+	}
 	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 
-	var bestResult BenchmarkResult
 	var singleGoroutineResult BenchmarkResult
-	bestPerformance := 0.0
 
 	for _, result := range results {
-		fmt.Printf("%-12d | %-15.2f | %-12d\n",
-			result.Goroutines, result.OpsPerSecond, result.TotalOps)
+		ciStr := "n/a"
+		if result.CIMargin > 0 {
+			ciStr = fmt.Sprintf("±%.2f", result.CIMargin)
+		}
+		if b.benchmem && result.MemProfile != nil {
+			fmt.Printf("%-12d | %-15.2f | %-12d | %-12.2f | %-12.2f | %-12.2f | %-12s | %-10.1f | %-12.1f | %-10.2f | %-6.1f | %-12s\n",
+				result.Goroutines, result.OpsPerSecond, result.TotalOps, result.StdDev, result.Min, result.Max, ciStr,
+				result.MemProfile.BytesPerOp, result.MemProfile.AllocsPerOp, result.MemProfile.MBPerSec,
+				result.MemProfile.GCs, result.MemProfile.AvgGCPause)
+		} else {
+			fmt.Printf("%-12d | %-15.2f | %-12d | %-12.2f | %-12.2f | %-12.2f | %-12s\n",
+				result.Goroutines, result.OpsPerSecond, result.TotalOps, result.StdDev, result.Min, result.Max, ciStr)
+		}
 
 		if result.Goroutines == 1 {
 			singleGoroutineResult = result
 		}
+	}
+
+	ranked := make([]BenchmarkResult, len(results))
+	copy(ranked, results)
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].OpsPerSecond > ranked[j].OpsPerSecond })
+	bestResult := ranked[0]
+
+	fmt.Printf("\n🏆 Optimal Configuration for %s:\n", testType)
 
-		if result.OpsPerSecond > bestPerformance {
-			bestPerformance = result.OpsPerSecond
-			bestResult = result
+	if len(ranked) > 1 {
+		runnerUp := ranked[1]
+		if ciOverlaps(bestResult, runnerUp) {
+			fmt.Printf("   Result: tied within noise (%d and %d goroutines overlap at 95%% CI)\n",
+				bestResult.Goroutines, runnerUp.Goroutines)
+			return
 		}
 	}
 
-	fmt.Printf("\n🏆 Optimal Configuration for %s:\n", testType)
 	fmt.Printf("   Goroutines: %d\n", bestResult.Goroutines)
 	cpuRatio := float64(bestResult.Goroutines) / float64(runtime.NumCPU())
 	fmt.Printf("   CPU Ratio: %.2fx\n", cpuRatio)
@@ -313,3 +716,28 @@ func (b *Benchmark) printResults(results []BenchmarkResult, testType string) {
 		fmt.Printf("   Speed Increase: %.2fx vs single goroutine\n", speedup)
 	}
 }
+
+// printResultsGoFormat writes one line per sample in the canonical
+// "go test -bench" layout so the output can be fed straight to benchstat:
+//
+//	BenchmarkCPU/goroutines=8-16   1234567   567.8 ns/op   0.0 B/op   0.0 allocs/op
+//
+// The trailing -N is GOMAXPROCS, matching what the standard testing package
+// appends to benchmark names. Each -count repeat is printed as its own line
+// under the same benchmark name, which is how benchstat recognizes repeated
+// samples of a configuration.
+func (b *Benchmark) printResultsGoFormat(results []BenchmarkResult, testType string) {
+	gomaxprocs := runtime.GOMAXPROCS(0)
+	for _, result := range results {
+		for _, s := range result.Samples {
+			nsPerOp := 1e9 / s.OpsPerSecond
+			var bytesPerOp, allocsPerOp float64
+			if s.Mem != nil && s.TotalOps > 0 {
+				bytesPerOp = float64(s.Mem.totalAlloc) / float64(s.TotalOps)
+				allocsPerOp = float64(s.Mem.mallocs) / float64(s.TotalOps)
+			}
+			fmt.Printf("Benchmark%s/goroutines=%d-%d\t%d\t%.1f ns/op\t%.1f B/op\t%.1f allocs/op\n",
+				testType, result.Goroutines, gomaxprocs, s.TotalOps, nsPerOp, bytesPerOp, allocsPerOp)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTValue(t *testing.T) {
+	cases := []struct {
+		df   int
+		want float64
+	}{
+		{df: 1, want: 12.706},
+		{df: 29, want: 2.045},
+		{df: 30, want: 2.042},
+		{df: 31, want: 1.960}, // past the table, falls back to the normal approximation
+		{df: 1000, want: 1.960},
+		{df: 0, want: 12.706}, // clamped to df=1
+		{df: -5, want: 12.706},
+	}
+	for _, c := range cases {
+		if got := tValue(c.df); got != c.want {
+			t.Errorf("tValue(%d) = %v, want %v", c.df, got, c.want)
+		}
+	}
+}
+
+func TestCIOverlaps(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b BenchmarkResult
+		want bool
+	}{
+		{
+			name: "clearly separated",
+			a:    BenchmarkResult{OpsPerSecond: 100, CIMargin: 2},
+			b:    BenchmarkResult{OpsPerSecond: 50, CIMargin: 2},
+			want: false,
+		},
+		{
+			name: "intervals touch",
+			a:    BenchmarkResult{OpsPerSecond: 100, CIMargin: 10},
+			b:    BenchmarkResult{OpsPerSecond: 85, CIMargin: 5},
+			want: true,
+		},
+		{
+			name: "no CI computed for a (single sample)",
+			a:    BenchmarkResult{OpsPerSecond: 100, CIMargin: 0},
+			b:    BenchmarkResult{OpsPerSecond: 100, CIMargin: 5},
+			want: false,
+		},
+		{
+			name: "identical duplicate configuration",
+			a:    BenchmarkResult{Goroutines: 1, OpsPerSecond: 100, CIMargin: 5},
+			b:    BenchmarkResult{Goroutines: 1, OpsPerSecond: 100, CIMargin: 5},
+			want: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ciOverlaps(c.a, c.b); got != c.want {
+				t.Errorf("ciOverlaps(%+v, %+v) = %v, want %v", c.a, c.b, got, c.want)
+			}
+		})
+	}
+}
+
+func TestAggregateSamplesStats(t *testing.T) {
+	samples := []Sample{
+		{OpsPerSecond: 100, TotalOps: 1000},
+		{OpsPerSecond: 110, TotalOps: 1100},
+		{OpsPerSecond: 90, TotalOps: 900},
+	}
+	result := aggregateSamples(4, samples)
+
+	if result.Goroutines != 4 {
+		t.Errorf("Goroutines = %d, want 4", result.Goroutines)
+	}
+	if result.OpsPerSecond != 100 {
+		t.Errorf("OpsPerSecond (mean) = %v, want 100", result.OpsPerSecond)
+	}
+	if result.Min != 90 {
+		t.Errorf("Min = %v, want 90", result.Min)
+	}
+	if result.Max != 110 {
+		t.Errorf("Max = %v, want 110", result.Max)
+	}
+	wantStdDev := math.Sqrt(100) // sample variance of {100,110,90} with n-1=2 divisor
+	if math.Abs(result.StdDev-wantStdDev) > 1e-9 {
+		t.Errorf("StdDev = %v, want %v", result.StdDev, wantStdDev)
+	}
+	if result.CIMargin <= 0 {
+		t.Errorf("CIMargin = %v, want > 0 for n=3 samples", result.CIMargin)
+	}
+}
+
+func TestAggregateSamplesSingleSampleHasNoCI(t *testing.T) {
+	result := aggregateSamples(1, []Sample{{OpsPerSecond: 100, TotalOps: 1000}})
+	if result.CIMargin != 0 {
+		t.Errorf("CIMargin = %v, want 0 for a single sample", result.CIMargin)
+	}
+	if result.StdDev != 0 {
+		t.Errorf("StdDev = %v, want 0 for a single sample", result.StdDev)
+	}
+}
+
+func TestAggregateMemProfileNilWithoutBenchmem(t *testing.T) {
+	samples := []Sample{{OpsPerSecond: 100, TotalOps: 1000}}
+	if got := aggregateMemProfile(samples); got != nil {
+		t.Errorf("aggregateMemProfile(no Mem) = %+v, want nil", got)
+	}
+}
+
+func TestAggregateMemProfile(t *testing.T) {
+	samples := []Sample{
+		{
+			TotalOps: 1000,
+			Elapsed:  time.Second,
+			Mem:      &memStatsDelta{totalAlloc: 1000, mallocs: 1000, numGC: 0, pauseNs: 0, heapObjects: 50},
+		},
+		{
+			TotalOps: 1000,
+			Elapsed:  time.Second,
+			Mem:      &memStatsDelta{totalAlloc: 3000, mallocs: 1000, numGC: 2, pauseNs: 2000, heapObjects: 90},
+		},
+	}
+
+	profile := aggregateMemProfile(samples)
+	if profile == nil {
+		t.Fatal("aggregateMemProfile = nil, want a profile")
+	}
+
+	if want := 2.0; profile.BytesPerOp != want {
+		t.Errorf("BytesPerOp = %v, want %v", profile.BytesPerOp, want)
+	}
+	if want := 1.0; profile.AllocsPerOp != want {
+		t.Errorf("AllocsPerOp = %v, want %v", profile.AllocsPerOp, want)
+	}
+	if want := 1.0; profile.GCs != want {
+		t.Errorf("GCs = %v, want %v", profile.GCs, want)
+	}
+	// Only the sample with numGC>0 contributes to the pause average.
+	if want := time.Microsecond; profile.AvgGCPause != want {
+		t.Errorf("AvgGCPause = %v, want %v", profile.AvgGCPause, want)
+	}
+	if want := uint64(90); profile.HeapObjects != want {
+		t.Errorf("HeapObjects (peak) = %v, want %v", profile.HeapObjects, want)
+	}
+}
+
+func TestRoundUpNice(t *testing.T) {
+	cases := []struct {
+		n, want int
+	}{
+		{n: -5, want: 1},
+		{n: 0, want: 1},
+		{n: 1, want: 1},
+		{n: 2, want: 2},
+		{n: 4, want: 5},
+		{n: 7, want: 10},
+		{n: 10, want: 10},
+		{n: 11, want: 20},
+		{n: 99, want: 100},
+		{n: 101, want: 200},
+	}
+	for _, c := range cases {
+		if got := roundUpNice(c.n); got != c.want {
+			t.Errorf("roundUpNice(%d) = %d, want %d", c.n, got, c.want)
+		}
+	}
+}
+
+func TestNextRampN(t *testing.T) {
+	const maxN = 1_000_000_000
+
+	// 1 iteration took 100ms and the target is 1s: should scale up ~10x,
+	// rounded to a nice number.
+	if got, want := nextRampN(1, 100*time.Millisecond, time.Second, maxN), 10; got != want {
+		t.Errorf("nextRampN(1, 100ms, 1s) = %d, want %d", got, want)
+	}
+
+	// Always grows by at least 1, even if the extrapolation says to shrink.
+	if got := nextRampN(5, time.Second, time.Millisecond, maxN); got <= 5 {
+		t.Errorf("nextRampN(5, 1s, 1ms) = %d, want > 5", got)
+	}
+
+	// A near-instant first pass must not overshoot by more than 100x.
+	if got, want := nextRampN(1, 0, time.Hour, maxN), 100; got != want {
+		t.Errorf("nextRampN(1, 0, 1h) = %d, want %d (capped at 100x growth)", got, want)
+	}
+
+	// Never exceeds the configured max.
+	if got, want := nextRampN(maxN, time.Millisecond, time.Hour, maxN), maxN; got != want {
+		t.Errorf("nextRampN(maxN, ...) = %d, want capped at %d", got, want)
+	}
+}
+
+func TestDurationOrCountFlagSet(t *testing.T) {
+	t.Run("duration", func(t *testing.T) {
+		var f durationOrCountFlag
+		if err := f.Set("10s"); err != nil {
+			t.Fatalf("Set(10s) error: %v", err)
+		}
+		if f.d != 10*time.Second || f.n != 0 {
+			t.Errorf("f = %+v, want d=10s n=0", f)
+		}
+		if got, want := f.String(), "10s"; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("count", func(t *testing.T) {
+		var f durationOrCountFlag
+		if err := f.Set("10000x"); err != nil {
+			t.Fatalf("Set(10000x) error: %v", err)
+		}
+		if f.n != 10000 || f.d != 0 {
+			t.Errorf("f = %+v, want n=10000 d=0", f)
+		}
+		if got, want := f.String(), "10000x"; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("invalid count", func(t *testing.T) {
+		var f durationOrCountFlag
+		if err := f.Set("0x"); err == nil {
+			t.Error("Set(0x) error = nil, want error for non-positive count")
+		}
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		var f durationOrCountFlag
+		if err := f.Set("not-a-duration"); err == nil {
+			t.Error("Set(not-a-duration) error = nil, want error")
+		}
+	})
+}
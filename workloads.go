@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"math"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// Workload is a pluggable unit of work the benchmark harness drives: Setup
+// runs before each -count repeat of a configuration, Step is called
+// repeatedly and concurrently by that repeat's goroutines, and Teardown
+// runs once they've all stopped. This mirrors the shape of
+// testing.InternalBenchmark closely enough that -run selection and the
+// registry below can borrow the same conventions.
+type Workload interface {
+	Name() string
+	Setup()
+	Step()
+	Teardown()
+}
+
+var registry = map[string]Workload{}
+var registryOrder []string
+
+// Register adds a workload to the package-level registry so it can be
+// selected with -run=<regexp>, matching the -test.bench convention.
+func Register(w Workload) {
+	name := w.Name()
+	if _, exists := registry[name]; !exists {
+		registryOrder = append(registryOrder, name)
+	}
+	registry[name] = w
+}
+
+func init() {
+	Register(&cpuWorkload{})
+	Register(&memoryWorkload{})
+	Register(&channelPingPongWorkload{})
+	Register(&mutexContentionWorkload{})
+	Register(&atomicCASWorkload{})
+	Register(&jsonWorkload{})
+	Register(&sha256Workload{})
+	Register(&syscallWorkload{})
+}
+
+// cpuWorkload is the original sqrt/sin/cos kernel: a single op is one pass
+// over the inner loop, with no shared state between goroutines.
+type cpuWorkload struct{}
+
+func (*cpuWorkload) Name() string { return "CPU" }
+func (*cpuWorkload) Setup()       {}
+func (*cpuWorkload) Teardown()    {}
+
+func (*cpuWorkload) Step() {
+	result := 0.0
+	for j := 0; j < 100; j++ {
+		result += math.Sqrt(float64(j))
+		result *= 1.0001
+		result = math.Sin(result) + math.Cos(result)
+	}
+	_ = result
+}
+
+// memoryWorkload allocates and touches a buffer per op, cycling through a
+// handful of sizes to exercise the allocator across size classes.
+type memoryWorkload struct {
+	sizes []int
+	next  uint64
+}
+
+func (*memoryWorkload) Name() string { return "Memory" }
+func (w *memoryWorkload) Setup()     { w.sizes = []int{64, 256, 1024, 4096} }
+func (*memoryWorkload) Teardown()    {}
+
+func (w *memoryWorkload) Step() {
+	i := atomic.AddUint64(&w.next, 1)
+	size := w.sizes[i%uint64(len(w.sizes))]
+	buffer := make([]byte, size)
+	for j := range buffer {
+		buffer[j] = byte(j % 256)
+	}
+	_ = buffer
+}
+
+// channelPingPongWorkload measures channel handoff overhead: every calling
+// goroutine round-trips a token through a single shared responder, so Step
+// contends for one channel pair the way a real producer/consumer would.
+type channelPingPongWorkload struct {
+	ping, pong chan struct{}
+	done       chan struct{}
+	stopped    chan struct{}
+}
+
+func (*channelPingPongWorkload) Name() string { return "ChannelPingPong" }
+
+func (w *channelPingPongWorkload) Setup() {
+	w.ping = make(chan struct{})
+	w.pong = make(chan struct{})
+	w.done = make(chan struct{})
+	w.stopped = make(chan struct{})
+	go func() {
+		defer close(w.stopped)
+		for {
+			select {
+			case <-w.ping:
+				w.pong <- struct{}{}
+			case <-w.done:
+				return
+			}
+		}
+	}()
+}
+
+func (w *channelPingPongWorkload) Step() {
+	w.ping <- struct{}{}
+	<-w.pong
+}
+
+func (w *channelPingPongWorkload) Teardown() {
+	close(w.done)
+	<-w.stopped
+}
+
+// mutexContentionWorkload has every goroutine lock, increment, and unlock a
+// single shared counter, so Step's cost grows with lock contention.
+type mutexContentionWorkload struct {
+	mu      sync.Mutex
+	counter int64
+}
+
+func (*mutexContentionWorkload) Name() string { return "MutexContention" }
+func (w *mutexContentionWorkload) Setup()     { w.counter = 0 }
+func (*mutexContentionWorkload) Teardown()    {}
+
+func (w *mutexContentionWorkload) Step() {
+	w.mu.Lock()
+	w.counter++
+	w.mu.Unlock()
+}
+
+// atomicCASWorkload has every goroutine spin a compare-and-swap loop against
+// a single shared counter, measuring contention without an OS-level lock.
+type atomicCASWorkload struct {
+	counter int64
+}
+
+func (*atomicCASWorkload) Name() string { return "AtomicCAS" }
+func (w *atomicCASWorkload) Setup()     { atomic.StoreInt64(&w.counter, 0) }
+func (*atomicCASWorkload) Teardown()    {}
+
+func (w *atomicCASWorkload) Step() {
+	for {
+		old := atomic.LoadInt64(&w.counter)
+		if atomic.CompareAndSwapInt64(&w.counter, old, old+1) {
+			return
+		}
+	}
+}
+
+// jsonRecord is the fixed payload jsonWorkload encodes and decodes.
+type jsonRecord struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Tags   []string `json:"tags"`
+	Active bool     `json:"active"`
+}
+
+// jsonWorkload round-trips a small struct through encoding/json, profiling
+// the allocator- and reflection-heavy path real services spend time in.
+type jsonWorkload struct {
+	record jsonRecord
+}
+
+func (*jsonWorkload) Name() string { return "JSON" }
+
+func (w *jsonWorkload) Setup() {
+	w.record = jsonRecord{ID: 1, Name: "go-bench", Tags: []string{"cpu", "memory", "concurrency"}, Active: true}
+}
+
+func (*jsonWorkload) Teardown() {}
+
+func (w *jsonWorkload) Step() {
+	data, err := json.Marshal(w.record)
+	if err != nil {
+		return
+	}
+	var decoded jsonRecord
+	_ = json.Unmarshal(data, &decoded)
+}
+
+// sha256Workload hashes a fixed-size buffer, profiling a pure-CPU kernel
+// that (unlike cpuWorkload) exercises crypto/sha256's SIMD-friendly path.
+type sha256Workload struct {
+	data []byte
+}
+
+func (*sha256Workload) Name() string { return "SHA256" }
+func (w *sha256Workload) Setup()     { w.data = bytes.Repeat([]byte("go-bench"), 128) }
+func (*sha256Workload) Teardown()    {}
+
+func (w *sha256Workload) Step() {
+	_ = sha256.Sum256(w.data)
+}
+
+// syscallWorkload issues a cheap syscall per op, profiling the cost of the
+// user/kernel transition itself under goroutine contention.
+type syscallWorkload struct{}
+
+func (*syscallWorkload) Name() string { return "Syscall" }
+func (*syscallWorkload) Setup()       {}
+func (*syscallWorkload) Teardown()    {}
+
+func (*syscallWorkload) Step() {
+	_ = syscall.Getpid()
+}